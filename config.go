@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/CGH0S7/gogpt/providers"
+	"github.com/CGH0S7/gogpt/tools"
+)
+
+// ProviderProfile is one named backend configuration. A Config can hold
+// several, letting the user switch between e.g. a local OpenAI-compatible
+// server and Anthropic without editing config.toml.
+type ProviderProfile struct {
+	Name        string `toml:"name"`
+	Type        string `toml:"type"` // "openai", "anthropic", "ollama", or "gemini"
+	APIEndpoint string `toml:"api_endpoint"`
+	APIKey      string `toml:"api_key"`
+	Model       string `toml:"model"`
+}
+
+// AgentConfig bundles a system prompt, model, and enabled tools under a
+// name the user can switch to with `/agent <name>`.
+type AgentConfig struct {
+	Name         string      `toml:"name"`
+	SystemPrompt string      `toml:"system_prompt"`
+	Provider     string      `toml:"provider"`
+	Model        string      `toml:"model"`
+	Tools        tools.Policy `toml:"tools"`
+}
+
+// Config holds the application configuration
+type Config struct {
+	APIEndpoint string            `toml:"api_endpoint"`
+	APIKey      string            `toml:"api_key"`
+	Model       string            `toml:"model"`
+	Username    string            `toml:"username"`
+	Provider    string            `toml:"provider"` // active profile name; empty means the legacy top-level fields
+	Profiles    []ProviderProfile `toml:"profile"`
+	Agents      []AgentConfig     `toml:"agent"`
+	TUI         bool              `toml:"tui"` // launch the full-screen interface by default when stdout is a TTY
+	MaxRetries  int               `toml:"max_retries"` // reconnect attempts for a dropped stream; 0 uses providers.DefaultMaxRetries
+}
+
+// agentByName finds a configured agent bundle by name.
+func agentByName(config *Config, name string) (AgentConfig, bool) {
+	for _, a := range config.Agents {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return AgentConfig{}, false
+}
+
+// configDir returns ~/.config/gogpt, creating no directories itself.
+func configDir() (string, error) {
+	usr, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("could not get current user: %w", err)
+	}
+	return filepath.Join(usr.HomeDir, ".config", "gogpt"), nil
+}
+
+// loadOrInitConfig loads config from file or prompts user for initial setup.
+func loadOrInitConfig() (*Config, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	configPath := filepath.Join(dir, "config.toml")
+
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		fmt.Println("Configuration file not found. Let's set it up.")
+		return promptForConfig(dir, configPath)
+	}
+
+	var config Config
+	if _, err := toml.DecodeFile(configPath, &config); err != nil {
+		return nil, fmt.Errorf("could not decode config file: %w", err)
+	}
+
+	// Handle case where username is missing from older configs
+	if config.Username == "" {
+		config.Username = "User"
+	}
+
+	fmt.Printf("Configuration loaded from %s\n", configPath)
+	return &config, nil
+}
+
+// promptForConfig interacts with the user to create the initial config file.
+func promptForConfig(configDir, configPath string) (*Config, error) {
+	reader := bufio.NewReader(os.Stdin)
+
+	// Prompt for API Endpoint
+	fmt.Print("Enter API Endpoint URL [http://127.0.0.1:8080/v1]: ")
+	endpoint, _ := reader.ReadString('\n')
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		endpoint = "http://127.0.0.1:8080/v1"
+	}
+
+	// Prompt for API Key (optional)
+	fmt.Print("Enter API Key (optional, press Enter to skip): ")
+	apiKey, _ := reader.ReadString('\n')
+	apiKey = strings.TrimSpace(apiKey)
+
+	// Prompt for Model Name
+	fmt.Print("Enter Model Name [gpt-oss-20b]: ")
+	model, _ := reader.ReadString('\n')
+	model = strings.TrimSpace(model)
+	if model == "" {
+		model = "gpt-oss-20b"
+	}
+
+	// Prompt for Username
+	fmt.Print("Enter your name to be displayed [User]: ")
+	username, _ := reader.ReadString('\n')
+	username = strings.TrimSpace(username)
+	if username == "" {
+		username = "User"
+	}
+
+	config := &Config{
+		APIEndpoint: endpoint,
+		APIKey:      apiKey,
+		Model:       model,
+		Username:    username,
+	}
+
+	// Save the new configuration
+	if err := saveConfig(config, configDir, configPath); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Configuration saved to %s\n", configPath)
+	return config, nil
+}
+
+// saveConfig saves the config struct to the specified TOML file.
+func saveConfig(config *Config, configDir, configPath string) error {
+	if err := os.MkdirAll(configDir, 0750); err != nil {
+		return fmt.Errorf("could not create config directory: %w", err)
+	}
+
+	file, err := os.Create(configPath)
+	if err != nil {
+		return fmt.Errorf("could not create config file: %w", err)
+	}
+	defer file.Close()
+
+	if err := toml.NewEncoder(file).Encode(config); err != nil {
+		return fmt.Errorf("could not encode config to file: %w", err)
+	}
+	return nil
+}
+
+// buildRegistry constructs a provider for each configured profile, plus a
+// "default" entry backed by the legacy top-level config fields so configs
+// written before profiles existed keep working unchanged.
+func buildRegistry(config *Config) (providers.Registry, error) {
+	registry := make(providers.Registry)
+	defaultProvider := providers.NewOpenAIProvider(config.APIEndpoint, config.APIKey)
+	if config.MaxRetries > 0 {
+		defaultProvider.MaxRetries = config.MaxRetries
+	}
+	registry["default"] = defaultProvider
+
+	for _, profile := range config.Profiles {
+		p, err := newProviderFromProfile(profile, config.MaxRetries)
+		if err != nil {
+			return nil, fmt.Errorf("profile %q: %w", profile.Name, err)
+		}
+		registry[profile.Name] = p
+	}
+
+	return registry, nil
+}
+
+// newProviderFromProfile builds the concrete Provider for a profile's type.
+// maxRetries overrides the OpenAI/Anthropic/Ollama adapters' reconnect-on-drop
+// count when positive (0 keeps providers.DefaultMaxRetries).
+func newProviderFromProfile(profile ProviderProfile, maxRetries int) (providers.Provider, error) {
+	switch profile.Type {
+	case "", "openai":
+		p := providers.NewOpenAIProvider(profile.APIEndpoint, profile.APIKey)
+		if maxRetries > 0 {
+			p.MaxRetries = maxRetries
+		}
+		return p, nil
+	case "anthropic":
+		p := providers.NewAnthropicProvider(profile.APIEndpoint, profile.APIKey)
+		if maxRetries > 0 {
+			p.MaxRetries = maxRetries
+		}
+		return p, nil
+	case "ollama":
+		p := providers.NewOllamaProvider(profile.APIEndpoint)
+		if maxRetries > 0 {
+			p.MaxRetries = maxRetries
+		}
+		return p, nil
+	case "gemini":
+		return providers.NewGeminiProvider(profile.APIEndpoint, profile.APIKey), nil
+	default:
+		return nil, fmt.Errorf("unknown provider type %q", profile.Type)
+	}
+}
+
+// modelForProfile resolves which model name to send for the named profile,
+// falling back to the legacy top-level Model field for "default".
+func modelForProfile(config *Config, name string) string {
+	for _, profile := range config.Profiles {
+		if profile.Name == name {
+			return profile.Model
+		}
+	}
+	return config.Model
+}
+
+// providerSupportsTools reports whether name's provider translates tool
+// schemas into its request at all. Only the legacy top-level fields and
+// "openai"-type profiles do (see providers/openai.go's toOpenAITools);
+// the Anthropic, Ollama, and Gemini adapters silently drop opts.Tools, so
+// an agent that enables tools against one of those would have the model
+// never told the tools exist, with no error anywhere to explain why.
+func providerSupportsTools(config *Config, name string) bool {
+	if name == "" || name == "default" {
+		return true
+	}
+	for _, profile := range config.Profiles {
+		if profile.Name == name {
+			return profile.Type == "" || profile.Type == "openai"
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+// Package conversations implements gogpt's persistent conversation store.
+// Conversations are saved as JSON trees under ~/.config/gogpt/conversations/
+// so sessions survive restarts. Messages form a DAG rather than a flat
+// list: editing an earlier message forks a new branch from that point
+// instead of overwriting history, mirroring how git branches work.
+package conversations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CGH0S7/gogpt/providers"
+)
+
+// Message is one node in a conversation's DAG. ParentID is empty for the
+// first message in a conversation (or in a branch's root import). Usage
+// fields are zero until a response comes back from the provider.
+// ToolCalls is set on an assistant message that invoked tools; Name and
+// ToolCallID identify which call a "tool" role message is answering.
+type Message struct {
+	ID           string              `json:"id"`
+	ParentID     string              `json:"parent_id,omitempty"`
+	Role         string              `json:"role"`
+	Content      string              `json:"content"`
+	Name         string              `json:"name,omitempty"`
+	ToolCallID   string              `json:"tool_call_id,omitempty"`
+	ToolCalls    []providers.ToolCall `json:"tool_calls,omitempty"`
+	Model        string              `json:"model,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+	InputTokens  int                 `json:"input_tokens,omitempty"`
+	OutputTokens int                 `json:"output_tokens,omitempty"`
+}
+
+// Branch names a leaf message so the REPL can offer it as a checkout
+// target. HeadID is the most recently added message on that branch.
+type Branch struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	HeadID string `json:"head_id"`
+}
+
+// Conversation is the on-disk unit: a title, the full set of messages
+// (addressable by ID, regardless of branch), and the set of known
+// branches. CurrentBranch is which one `/view` and new messages target.
+type Conversation struct {
+	ID            string             `json:"id"`
+	Title         string             `json:"title"`
+	CreatedAt     time.Time          `json:"created_at"`
+	Messages      map[string]Message `json:"messages"`
+	Branches      []Branch           `json:"branches"`
+	CurrentBranch string             `json:"current_branch"`
+}
+
+// Path returns the linear message history from the conversation root down
+// to the given leaf ID, suitable for handing straight to a Provider.
+func (c *Conversation) Path(leafID string) ([]Message, error) {
+	var path []Message
+	id := leafID
+	for id != "" {
+		msg, ok := c.Messages[id]
+		if !ok {
+			return nil, fmt.Errorf("conversation %s: unknown message %s", c.ID, id)
+		}
+		path = append([]Message{msg}, path...)
+		id = msg.ParentID
+	}
+	return path, nil
+}
+
+// Head returns the head message ID of the current branch.
+func (c *Conversation) Head() string {
+	for _, b := range c.Branches {
+		if b.Name == c.CurrentBranch {
+			return b.HeadID
+		}
+	}
+	return ""
+}
+
+// setBranchHead moves the named branch's head, creating it if it doesn't
+// exist yet.
+func (c *Conversation) setBranchHead(name, headID string) {
+	for i := range c.Branches {
+		if c.Branches[i].Name == name {
+			c.Branches[i].HeadID = headID
+			return
+		}
+	}
+	c.Branches = append(c.Branches, Branch{ID: newID(), Name: name, HeadID: headID})
+}
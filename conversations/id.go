@@ -0,0 +1,36 @@
+package conversations
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// newID returns a random 16-byte hex identifier. gogpt has no existing
+// UUID dependency, and these IDs never leave the local JSON store, so a
+// plain random hex string is preferred over pulling in a new module.
+func newID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand failing is effectively unrecoverable on any real
+		// system; panic rather than silently handing out colliding IDs.
+		panic(fmt.Sprintf("conversations: could not generate id: %v", err))
+	}
+	return fmt.Sprintf("%x", buf)
+}
+
+// validID reports whether id has exactly the shape newID produces: 32
+// lowercase hex characters. Store.path rejects anything else before
+// joining it into a filename, since conversation IDs used there
+// ultimately come from user input (`/open`, `/rm`) and must not be able
+// to escape the conversations directory via "/" or "..".
+func validID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	for _, r := range id {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
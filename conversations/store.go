@@ -0,0 +1,195 @@
+package conversations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/CGH0S7/gogpt/providers"
+)
+
+const mainBranch = "main"
+
+// Store reads and writes conversations as one JSON file per conversation
+// under Dir.
+type Store struct {
+	Dir string
+}
+
+// NewStore returns a Store rooted at dir, creating it if necessary.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("could not create conversations directory: %w", err)
+	}
+	return &Store{Dir: dir}, nil
+}
+
+func (s *Store) path(id string) (string, error) {
+	if !validID(id) {
+		return "", fmt.Errorf("invalid conversation id %q", id)
+	}
+	return filepath.Join(s.Dir, id+".json"), nil
+}
+
+// Create starts a new conversation with a single root message (typically
+// the system prompt) and persists it.
+func (s *Store) Create(title, systemPrompt, model string) (*Conversation, error) {
+	now := time.Now()
+	root := Message{ID: newID(), Role: "system", Content: systemPrompt, Model: model, CreatedAt: now}
+
+	conv := &Conversation{
+		ID:            newID(),
+		Title:         title,
+		CreatedAt:     now,
+		Messages:      map[string]Message{root.ID: root},
+		CurrentBranch: mainBranch,
+	}
+	conv.setBranchHead(mainBranch, root.ID)
+
+	if err := s.Save(conv); err != nil {
+		return nil, err
+	}
+	return conv, nil
+}
+
+// Save writes conv to disk, overwriting any existing file for its ID.
+func (s *Store) Save(conv *Conversation) error {
+	data, err := json.MarshalIndent(conv, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal conversation: %w", err)
+	}
+	p, err := s.path(conv.ID)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(p, data, 0600); err != nil {
+		return fmt.Errorf("could not write conversation file: %w", err)
+	}
+	return nil
+}
+
+// Open loads a conversation by ID.
+func (s *Store) Open(id string) (*Conversation, error) {
+	p, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("could not read conversation %s: %w", id, err)
+	}
+	var conv Conversation
+	if err := json.Unmarshal(data, &conv); err != nil {
+		return nil, fmt.Errorf("could not parse conversation %s: %w", id, err)
+	}
+	return &conv, nil
+}
+
+// Summary is the lightweight listing used by `/list`.
+type Summary struct {
+	ID        string
+	Title     string
+	CreatedAt time.Time
+}
+
+// List returns a summary of every saved conversation, most recently
+// created first.
+func (s *Store) List() ([]Summary, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read conversations directory: %w", err)
+	}
+
+	var summaries []Summary
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		conv, err := s.Open(entry.Name()[:len(entry.Name())-len(".json")])
+		if err != nil {
+			continue
+		}
+		summaries = append(summaries, Summary{ID: conv.ID, Title: conv.Title, CreatedAt: conv.CreatedAt})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].CreatedAt.After(summaries[j].CreatedAt) })
+	return summaries, nil
+}
+
+// Remove deletes a conversation's file from disk.
+func (s *Store) Remove(id string) error {
+	p, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil {
+		return fmt.Errorf("could not remove conversation %s: %w", id, err)
+	}
+	return nil
+}
+
+// AppendMessage adds a new message as a child of parentID, moves the
+// current branch's head to it, and saves the conversation. inputTokens
+// and outputTokens are the usage the provider reported for this turn (0
+// when the message isn't a model reply, e.g. the user's own input).
+func (s *Store) AppendMessage(conv *Conversation, parentID, role, content, model string, inputTokens, outputTokens int) (Message, error) {
+	return s.appendMessage(conv, Message{ParentID: parentID, Role: role, Content: content, Model: model, InputTokens: inputTokens, OutputTokens: outputTokens})
+}
+
+// AppendToolResult appends a "tool" role message answering toolCallID, as
+// produced by dispatching one of the assistant's requested tool calls.
+func (s *Store) AppendToolResult(conv *Conversation, parentID, name, toolCallID, content string) (Message, error) {
+	return s.appendMessage(conv, Message{ParentID: parentID, Role: "tool", Name: name, ToolCallID: toolCallID, Content: content})
+}
+
+// AppendToolCalls appends an assistant message that requested one or more
+// tool calls instead of (or alongside) producing final text.
+func (s *Store) AppendToolCalls(conv *Conversation, parentID, content, model string, calls []providers.ToolCall, inputTokens, outputTokens int) (Message, error) {
+	return s.appendMessage(conv, Message{ParentID: parentID, Role: "assistant", Content: content, Model: model, ToolCalls: calls, InputTokens: inputTokens, OutputTokens: outputTokens})
+}
+
+func (s *Store) appendMessage(conv *Conversation, msg Message) (Message, error) {
+	msg.ID = newID()
+	msg.CreatedAt = time.Now()
+	conv.Messages[msg.ID] = msg
+	conv.setBranchHead(conv.CurrentBranch, msg.ID)
+	if err := s.Save(conv); err != nil {
+		return Message{}, err
+	}
+	return msg, nil
+}
+
+// Fork creates a new branch rooted at fromMsgID (typically the parent of
+// an edited message) and switches the conversation onto it. The new
+// branch's name is derived from its position so repeated edits of the
+// same message don't collide.
+func (s *Store) Fork(conv *Conversation, fromMsgID string) (string, error) {
+	if fromMsgID == "" {
+		return "", fmt.Errorf("conversation %s: cannot fork from the root message", conv.ID)
+	}
+	if _, ok := conv.Messages[fromMsgID]; !ok {
+		return "", fmt.Errorf("conversation %s: unknown message %s", conv.ID, fromMsgID)
+	}
+	branchName := fmt.Sprintf("branch-%d", len(conv.Branches))
+	conv.setBranchHead(branchName, fromMsgID)
+	conv.CurrentBranch = branchName
+	if err := s.Save(conv); err != nil {
+		return "", err
+	}
+	return branchName, nil
+}
+
+// Checkout switches the conversation's current branch, returning an error
+// if the branch doesn't exist.
+func (s *Store) Checkout(conv *Conversation, branchName string) error {
+	for _, b := range conv.Branches {
+		if b.Name == branchName {
+			conv.CurrentBranch = branchName
+			return s.Save(conv)
+		}
+	}
+	return fmt.Errorf("conversation %s: unknown branch %s", conv.ID, branchName)
+}
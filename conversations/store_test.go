@@ -0,0 +1,88 @@
+package conversations
+
+import "testing"
+
+func TestPathForkAndCheckout(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	conv, err := store.Create("test", "system prompt", "gpt-test")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	root := conv.Head()
+
+	first, err := store.AppendMessage(conv, root, "user", "hello", "gpt-test", 0, 0)
+	if err != nil {
+		t.Fatalf("AppendMessage (user): %v", err)
+	}
+	second, err := store.AppendMessage(conv, first.ID, "assistant", "hi there", "gpt-test", 3, 5)
+	if err != nil {
+		t.Fatalf("AppendMessage (assistant): %v", err)
+	}
+
+	path, err := conv.Path(second.ID)
+	if err != nil {
+		t.Fatalf("Path: %v", err)
+	}
+	if len(path) != 3 {
+		t.Fatalf("expected 3 messages in path, got %d", len(path))
+	}
+	if path[0].Role != "system" || path[1].Role != "user" || path[2].Role != "assistant" {
+		t.Fatalf("unexpected path roles: %+v", path)
+	}
+	if path[2].InputTokens != 3 || path[2].OutputTokens != 5 {
+		t.Fatalf("expected usage to round-trip onto the stored message, got %+v", path[2])
+	}
+
+	if _, err := store.Fork(conv, ""); err == nil {
+		t.Fatal("expected Fork from the root message to fail")
+	}
+
+	branch, err := store.Fork(conv, first.ID)
+	if err != nil {
+		t.Fatalf("Fork: %v", err)
+	}
+	if conv.CurrentBranch != branch {
+		t.Fatalf("Fork did not switch the conversation onto the new branch")
+	}
+	if conv.Head() != first.ID {
+		t.Fatalf("forked branch head = %s, want %s", conv.Head(), first.ID)
+	}
+
+	if err := store.Checkout(conv, mainBranch); err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+	if conv.Head() != second.ID {
+		t.Fatalf("after checkout, head = %s, want %s", conv.Head(), second.ID)
+	}
+
+	if err := store.Checkout(conv, "does-not-exist"); err == nil {
+		t.Fatal("expected Checkout to an unknown branch to fail")
+	}
+}
+
+func TestPathUnknownMessage(t *testing.T) {
+	conv := &Conversation{ID: "c", Messages: map[string]Message{}}
+	if _, err := conv.Path("missing"); err == nil {
+		t.Fatal("expected Path to an unknown message id to fail")
+	}
+}
+
+func TestOpenAndRemoveRejectPathTraversal(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	for _, bad := range []string{"../evil", "a/b", "..", ""} {
+		if _, err := store.Open(bad); err == nil {
+			t.Errorf("Open(%q): expected error, got nil", bad)
+		}
+		if err := store.Remove(bad); err == nil {
+			t.Errorf("Remove(%q): expected error, got nil", bad)
+		}
+	}
+}
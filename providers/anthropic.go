@@ -0,0 +1,222 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AnthropicProvider talks to Anthropic's /v1/messages API, which uses a
+// distinct request shape (a top-level "system" field, a "content" array
+// per message) and a distinct SSE event stream (content_block_delta,
+// message_delta carrying usage, message_stop).
+type AnthropicProvider struct {
+	Endpoint   string
+	APIKey     string
+	Version    string
+	MaxRetries int
+	Client     *http.Client
+}
+
+// NewAnthropicProvider builds an adapter for the Anthropic Messages API.
+func NewAnthropicProvider(endpoint, apiKey string) *AnthropicProvider {
+	return &AnthropicProvider{
+		Endpoint:   strings.TrimSuffix(endpoint, "/"),
+		APIKey:     apiKey,
+		Version:    "2023-06-01",
+		MaxRetries: DefaultMaxRetries,
+		Client:     &http.Client{},
+	}
+}
+
+func (p *AnthropicProvider) Name() string { return "anthropic" }
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	Stream    bool               `json:"stream"`
+	MaxTokens int                `json:"max_tokens"`
+}
+
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// toAnthropicRequest splits off any leading system message (Anthropic takes
+// it as a top-level field, not a message with role "system") and converts
+// the rest directly.
+func toAnthropicRequest(model string, messages []ChatMessage) anthropicRequest {
+	req := anthropicRequest{Model: model, Stream: true, MaxTokens: 4096}
+	for _, m := range messages {
+		if m.Role == "system" {
+			req.System = m.Content
+			continue
+		}
+		req.Messages = append(req.Messages, anthropicMessage{Role: m.Role, Content: m.Content})
+	}
+	return req
+}
+
+// StreamChat streams a chat turn, reconnecting with exponential backoff
+// if the connection drops mid-response, the same as OpenAIProvider: each
+// reconnect replays the original conversation with the partial assistant
+// reply already received appended, plus a nudge to continue from there.
+func (p *AnthropicProvider) StreamChat(ctx context.Context, messages []ChatMessage, opts Options) (<-chan Delta, error) {
+	out := make(chan Delta)
+
+	go func() {
+		defer close(out)
+
+		var partial strings.Builder
+		attempt := 0
+
+		for {
+			reqMessages := messages
+			if partial.Len() > 0 {
+				reqMessages = append(append([]ChatMessage{}, messages...),
+					ChatMessage{Role: "assistant", Content: partial.String()},
+					ChatMessage{Role: "user", Content: "Continue your previous response exactly where it left off. Do not repeat anything already written."},
+				)
+			}
+
+			reconnect, err := p.streamOnce(ctx, reqMessages, opts, &partial, out)
+			if !reconnect {
+				return
+			}
+
+			attempt++
+			if attempt > p.MaxRetries {
+				select {
+				case out <- Delta{FinishReason: "error", Err: fmt.Errorf("stream failed after %d attempts: %w", attempt, err)}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// streamOnce performs a single HTTP attempt; see OpenAIProvider.streamOnce
+// for the reconnect/false contract this follows.
+func (p *AnthropicProvider) streamOnce(ctx context.Context, messages []ChatMessage, opts Options, partial *strings.Builder, out chan<- Delta) (reconnect bool, err error) {
+	chatEndpoint := fmt.Sprintf("%s/messages", p.Endpoint)
+
+	reqBody := toAnthropicRequest(opts.Model, messages)
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return false, p.emitFatal(ctx, out, fmt.Errorf("could not marshal request: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", chatEndpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return false, p.emitFatal(ctx, out, fmt.Errorf("could not create HTTP request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("anthropic-version", p.Version)
+	if p.APIKey != "" {
+		req.Header.Set("x-api-key", p.APIKey)
+	}
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, nil // caller canceled; nothing left to report
+		}
+		return true, err // transport error before any bytes: retryable
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return false, p.emitFatal(ctx, out, fmt.Errorf("received non-OK HTTP status: %s, Body: %s", resp.Status, string(bodyBytes)))
+	}
+
+	sawFinish := false
+
+	scanErr := scanSSE(resp.Body, func(ev sseEvent) bool {
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(ev.Data), &event); err != nil {
+			return false // skip malformed lines rather than aborting the turn
+		}
+
+		switch event.Type {
+		case "content_block_delta":
+			if event.Delta.Type != "text_delta" {
+				return false
+			}
+			partial.WriteString(event.Delta.Text)
+			select {
+			case out <- Delta{Content: event.Delta.Text}:
+			case <-ctx.Done():
+				return true
+			}
+			return false
+		case "message_delta":
+			select {
+			case out <- Delta{OutputTokens: event.Usage.OutputTokens}:
+			case <-ctx.Done():
+				return true
+			}
+			return false
+		case "message_stop":
+			select {
+			case out <- Delta{FinishReason: "stop"}:
+			case <-ctx.Done():
+			}
+			sawFinish = true
+			return true
+		default:
+			return false
+		}
+	})
+
+	if sawFinish || ctx.Err() != nil {
+		return false, nil
+	}
+	if scanErr != nil {
+		return true, scanErr // dropped mid-stream: retryable
+	}
+	// Stream closed cleanly without a message_stop event: treat it the
+	// same as a drop so the caller reconnects instead of losing text.
+	return true, fmt.Errorf("stream ended without a finish reason")
+}
+
+// emitFatal sends a terminal error Delta (unless the caller already
+// canceled ctx) and returns the same error so callers can keep their
+// existing `return ..., err` shape at the call site.
+func (p *AnthropicProvider) emitFatal(ctx context.Context, out chan<- Delta, err error) error {
+	select {
+	case out <- Delta{FinishReason: "error", Err: err}:
+	case <-ctx.Done():
+	}
+	return err
+}
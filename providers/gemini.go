@@ -0,0 +1,142 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GeminiProvider talks to Google's generateContent API. Gemini has no
+// streaming SSE mode on the plain REST endpoint used here, so StreamChat
+// issues a single blocking request and emits the whole reply as one Delta
+// followed immediately by the final FinishReason Delta.
+type GeminiProvider struct {
+	Endpoint string
+	APIKey   string
+	Client   *http.Client
+}
+
+// NewGeminiProvider builds an adapter for the Gemini generateContent API.
+func NewGeminiProvider(endpoint, apiKey string) *GeminiProvider {
+	return &GeminiProvider{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		APIKey:   apiKey,
+		Client:   &http.Client{},
+	}
+}
+
+func (p *GeminiProvider) Name() string { return "gemini" }
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiRequest struct {
+	Contents []geminiContent `json:"contents"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
+}
+
+// toGeminiContents maps our role names onto Gemini's "user"/"model" roles
+// and folds any leading system message into the first user turn, since
+// Gemini's generateContent has no dedicated system role on this endpoint.
+func toGeminiContents(messages []ChatMessage) []geminiContent {
+	var system string
+	var contents []geminiContent
+	for _, m := range messages {
+		switch m.Role {
+		case "system":
+			system = m.Content
+		case "assistant":
+			contents = append(contents, geminiContent{Role: "model", Parts: []geminiPart{{Text: m.Content}}})
+		default:
+			text := m.Content
+			if system != "" {
+				text = system + "\n\n" + text
+				system = ""
+			}
+			contents = append(contents, geminiContent{Role: "user", Parts: []geminiPart{{Text: text}}})
+		}
+	}
+	return contents
+}
+
+func (p *GeminiProvider) StreamChat(ctx context.Context, messages []ChatMessage, opts Options) (<-chan Delta, error) {
+	genEndpoint := fmt.Sprintf("%s/models/%s:generateContent?key=%s", p.Endpoint, opts.Model, p.APIKey)
+
+	reqBody := geminiRequest{Contents: toGeminiContents(messages)}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", genEndpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("could not create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received non-OK HTTP status: %s, Body: %s", resp.Status, string(bodyBytes))
+	}
+
+	var genResp geminiResponse
+	if err := json.Unmarshal(bodyBytes, &genResp); err != nil {
+		return nil, fmt.Errorf("could not unmarshal response: %w", err)
+	}
+
+	out := make(chan Delta, 2)
+	go func() {
+		defer close(out)
+		if len(genResp.Candidates) == 0 {
+			out <- Delta{FinishReason: "stop"}
+			return
+		}
+		candidate := genResp.Candidates[0]
+		var text strings.Builder
+		for _, part := range candidate.Content.Parts {
+			text.WriteString(part.Text)
+		}
+
+		select {
+		case out <- Delta{Content: text.String()}:
+		case <-ctx.Done():
+			return
+		}
+		out <- Delta{
+			FinishReason: strings.ToLower(candidate.FinishReason),
+			InputTokens:  genResp.UsageMetadata.PromptTokenCount,
+			OutputTokens: genResp.UsageMetadata.CandidatesTokenCount,
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,183 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OllamaProvider talks to a local Ollama server's /api/chat endpoint. Unlike
+// the OpenAI and Anthropic adapters, Ollama streams newline-delimited JSON
+// objects rather than Server-Sent Events, so there is no "data:" prefix to
+// strip and no [DONE] sentinel; the last object carries "done": true.
+type OllamaProvider struct {
+	Endpoint   string
+	MaxRetries int
+	Client     *http.Client
+}
+
+// NewOllamaProvider builds an adapter for a local Ollama server.
+func NewOllamaProvider(endpoint string) *OllamaProvider {
+	return &OllamaProvider{
+		Endpoint:   strings.TrimSuffix(endpoint, "/"),
+		MaxRetries: DefaultMaxRetries,
+		Client:     &http.Client{},
+	}
+}
+
+func (p *OllamaProvider) Name() string { return "ollama" }
+
+type ollamaRequest struct {
+	Model    string        `json:"model"`
+	Messages []ChatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+}
+
+type ollamaResponseLine struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done            bool `json:"done"`
+	PromptEvalCount int  `json:"prompt_eval_count"`
+	EvalCount       int  `json:"eval_count"`
+}
+
+// StreamChat streams a chat turn, reconnecting with exponential backoff
+// if the connection drops mid-response, the same as OpenAIProvider: each
+// reconnect replays the original conversation with the partial assistant
+// reply already received appended, plus a nudge to continue from there.
+func (p *OllamaProvider) StreamChat(ctx context.Context, messages []ChatMessage, opts Options) (<-chan Delta, error) {
+	out := make(chan Delta)
+
+	go func() {
+		defer close(out)
+
+		var partial strings.Builder
+		attempt := 0
+
+		for {
+			reqMessages := messages
+			if partial.Len() > 0 {
+				reqMessages = append(append([]ChatMessage{}, messages...),
+					ChatMessage{Role: "assistant", Content: partial.String()},
+					ChatMessage{Role: "user", Content: "Continue your previous response exactly where it left off. Do not repeat anything already written."},
+				)
+			}
+
+			reconnect, err := p.streamOnce(ctx, reqMessages, opts, &partial, out)
+			if !reconnect {
+				return
+			}
+
+			attempt++
+			if attempt > p.MaxRetries {
+				select {
+				case out <- Delta{FinishReason: "error", Err: fmt.Errorf("stream failed after %d attempts: %w", attempt, err)}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// streamOnce performs a single HTTP attempt; see OpenAIProvider.streamOnce
+// for the reconnect/false contract this follows.
+func (p *OllamaProvider) streamOnce(ctx context.Context, messages []ChatMessage, opts Options, partial *strings.Builder, out chan<- Delta) (reconnect bool, err error) {
+	chatEndpoint := fmt.Sprintf("%s/api/chat", p.Endpoint)
+
+	reqBody := ollamaRequest{Model: opts.Model, Messages: messages, Stream: true}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return false, p.emitFatal(ctx, out, fmt.Errorf("could not marshal request: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", chatEndpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return false, p.emitFatal(ctx, out, fmt.Errorf("could not create HTTP request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, nil // caller canceled; nothing left to report
+		}
+		return true, err // transport error before any bytes: retryable
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, p.emitFatal(ctx, out, fmt.Errorf("received non-OK HTTP status: %s", resp.Status))
+	}
+
+	sawFinish := false
+	streamReader := bufio.NewReader(resp.Body)
+
+	for {
+		line, readErr := streamReader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line != "" {
+			var obj ollamaResponseLine
+			if err := json.Unmarshal([]byte(line), &obj); err == nil {
+				delta := Delta{Content: obj.Message.Content}
+				if obj.Done {
+					delta.FinishReason = "stop"
+					delta.InputTokens = obj.PromptEvalCount
+					delta.OutputTokens = obj.EvalCount
+				}
+				if delta.Content != "" {
+					partial.WriteString(delta.Content)
+				}
+
+				select {
+				case out <- delta:
+				case <-ctx.Done():
+					return false, nil
+				}
+
+				if obj.Done {
+					sawFinish = true
+					break
+				}
+			}
+			// malformed line: skip it rather than aborting the turn
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	if sawFinish || ctx.Err() != nil {
+		return false, nil
+	}
+	// Stream closed before a "done": true object: treat it the same as a
+	// drop so the caller reconnects instead of losing text.
+	return true, fmt.Errorf("stream ended without a finish reason")
+}
+
+// emitFatal sends a terminal error Delta (unless the caller already
+// canceled ctx) and returns the same error so callers can keep their
+// existing `return ..., err` shape at the call site.
+func (p *OllamaProvider) emitFatal(ctx context.Context, out chan<- Delta, err error) error {
+	select {
+	case out <- Delta{FinishReason: "error", Err: err}:
+	case <-ctx.Done():
+	}
+	return err
+}
@@ -0,0 +1,312 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultMaxRetries is how many times StreamChat reconnects after a
+// mid-stream transport error before giving up and surfacing it.
+const DefaultMaxRetries = 3
+
+// OpenAIProvider talks to any OpenAI-compatible /v1/chat/completions
+// endpoint (llama.cpp, vLLM, LM Studio, the real OpenAI API, ...). This is
+// the backend gogpt originally had in main.go, now with tool calling and
+// reconnect-on-drop added.
+type OpenAIProvider struct {
+	Endpoint   string
+	APIKey     string
+	MaxRetries int
+	Client     *http.Client
+}
+
+// NewOpenAIProvider builds an adapter for an OpenAI-compatible endpoint.
+func NewOpenAIProvider(endpoint, apiKey string) *OpenAIProvider {
+	return &OpenAIProvider{
+		Endpoint:   strings.TrimSuffix(endpoint, "/"),
+		APIKey:     apiKey,
+		MaxRetries: DefaultMaxRetries,
+		Client:     &http.Client{},
+	}
+}
+
+func (p *OpenAIProvider) Name() string { return "openai" }
+
+type openAIFunction struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type openAITool struct {
+	Type     string         `json:"type"`
+	Function openAIFunction `json:"function"`
+}
+
+type openAIToolCall struct {
+	Index    int    `json:"index"`
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    string           `json:"content"`
+	Name       string           `json:"name,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+}
+
+type openAIChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []openAIMessage `json:"messages"`
+	Tools    []openAITool    `json:"tools,omitempty"`
+	Stream   bool            `json:"stream"`
+}
+
+type openAIStreamChoice struct {
+	Delta struct {
+		Content   string           `json:"content"`
+		ToolCalls []openAIToolCall `json:"tool_calls"`
+	} `json:"delta"`
+	FinishReason interface{} `json:"finish_reason"`
+}
+
+type openAIStreamResponse struct {
+	Choices []openAIStreamChoice `json:"choices"`
+}
+
+func toOpenAIMessages(messages []ChatMessage) []openAIMessage {
+	out := make([]openAIMessage, len(messages))
+	for i, m := range messages {
+		om := openAIMessage{Role: m.Role, Content: m.Content, Name: m.Name, ToolCallID: m.ToolCallID}
+		for _, tc := range m.ToolCalls {
+			call := openAIToolCall{ID: tc.ID, Type: "function"}
+			call.Function.Name = tc.Name
+			call.Function.Arguments = tc.Arguments
+			om.ToolCalls = append(om.ToolCalls, call)
+		}
+		out[i] = om
+	}
+	return out
+}
+
+func toOpenAITools(tools []Tool) []openAITool {
+	if len(tools) == 0 {
+		return nil
+	}
+	out := make([]openAITool, len(tools))
+	for i, t := range tools {
+		out[i] = openAITool{
+			Type: "function",
+			Function: openAIFunction{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.Schema,
+			},
+		}
+	}
+	return out
+}
+
+// StreamChat streams a chat turn, reconnecting with exponential backoff
+// if the connection drops mid-response. On each reconnect it replays the
+// original conversation with the partial assistant reply already
+// received appended, plus a nudge to continue from there, so the text the
+// caller sees is one continuous stream stitched across attempts.
+func (p *OpenAIProvider) StreamChat(ctx context.Context, messages []ChatMessage, opts Options) (<-chan Delta, error) {
+	out := make(chan Delta)
+
+	go func() {
+		defer close(out)
+
+		var partial strings.Builder
+		attempt := 0
+
+		for {
+			reqMessages := messages
+			if partial.Len() > 0 {
+				reqMessages = append(append([]ChatMessage{}, messages...),
+					ChatMessage{Role: "assistant", Content: partial.String()},
+					ChatMessage{Role: "user", Content: "Continue your previous response exactly where it left off. Do not repeat anything already written."},
+				)
+			}
+
+			reconnect, err := p.streamOnce(ctx, reqMessages, opts, &partial, out)
+			if !reconnect {
+				// A terminal delta (stop, tool_calls, a fatal upstream
+				// error, or caller cancellation) was already emitted by
+				// streamOnce.
+				return
+			}
+
+			attempt++
+			if attempt > p.MaxRetries {
+				select {
+				case out <- Delta{Content: "", FinishReason: "error", Err: fmt.Errorf("stream failed after %d attempts: %w", attempt, err)}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// streamOnce performs a single HTTP attempt. It returns reconnect=true
+// when the connection dropped mid-stream (or failed to establish) and
+// the caller should back off and retry with the accumulated partial text
+// stitched into a continuation request; reconnect=false means a terminal
+// Delta (stop, tool_calls, a fatal error, or ctx cancellation) has
+// already been sent on out and the caller should stop.
+func (p *OpenAIProvider) streamOnce(ctx context.Context, messages []ChatMessage, opts Options, partial *strings.Builder, out chan<- Delta) (reconnect bool, err error) {
+	chatEndpoint := fmt.Sprintf("%s/chat/completions", p.Endpoint)
+
+	reqBody := openAIChatRequest{
+		Model:    opts.Model,
+		Messages: toOpenAIMessages(messages),
+		Tools:    toOpenAITools(opts.Tools),
+		Stream:   true,
+	}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return false, p.emitFatal(ctx, out, fmt.Errorf("could not marshal request: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", chatEndpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return false, p.emitFatal(ctx, out, fmt.Errorf("could not create HTTP request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, nil // caller canceled; nothing left to report
+		}
+		return true, err // transport error before any bytes: retryable
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return false, p.emitFatal(ctx, out, fmt.Errorf("received non-OK HTTP status: %s, Body: %s", resp.Status, string(bodyBytes)))
+	}
+
+	pending := map[int]*ToolCall{}
+	var order []int
+	sawFinish := false
+
+	scanErr := scanSSE(resp.Body, func(ev sseEvent) bool {
+		if ev.Data == "[DONE]" {
+			// [DONE] without a preceding finish_reason still ends the turn.
+			select {
+			case out <- Delta{FinishReason: "stop"}:
+			case <-ctx.Done():
+			}
+			sawFinish = true
+			return true
+		}
+
+		var streamResp openAIStreamResponse
+		if err := json.Unmarshal([]byte(ev.Data), &streamResp); err != nil {
+			return false // skip malformed lines rather than aborting the turn
+		}
+		if len(streamResp.Choices) == 0 {
+			return false
+		}
+
+		choice := streamResp.Choices[0]
+		for _, tc := range choice.Delta.ToolCalls {
+			call, ok := pending[tc.Index]
+			if !ok {
+				call = &ToolCall{}
+				pending[tc.Index] = call
+				order = append(order, tc.Index)
+			}
+			if tc.ID != "" {
+				call.ID = tc.ID
+			}
+			if tc.Function.Name != "" {
+				call.Name = tc.Function.Name
+			}
+			call.Arguments += tc.Function.Arguments
+		}
+
+		delta := Delta{Content: choice.Delta.Content}
+		if choice.FinishReason != nil {
+			if reason, ok := choice.FinishReason.(string); ok {
+				delta.FinishReason = reason
+			}
+		}
+
+		if delta.Content != "" {
+			partial.WriteString(delta.Content)
+		}
+		if delta.FinishReason == "tool_calls" {
+			for _, idx := range order {
+				delta.ToolCalls = append(delta.ToolCalls, *pending[idx])
+			}
+		}
+
+		if delta.FinishReason == "" && delta.Content == "" {
+			return false
+		}
+
+		select {
+		case out <- delta:
+		case <-ctx.Done():
+			return true
+		}
+
+		if delta.FinishReason != "" {
+			sawFinish = true
+			return true
+		}
+		return false
+	})
+
+	if sawFinish || ctx.Err() != nil {
+		return false, nil
+	}
+	if scanErr != nil {
+		return true, scanErr // dropped mid-stream: retryable
+	}
+	// Stream closed cleanly without a finish_reason or [DONE]: treat it
+	// the same as a drop so the caller reconnects instead of losing text.
+	return true, fmt.Errorf("stream ended without a finish reason")
+}
+
+// emitFatal sends a terminal error Delta (unless the caller already
+// canceled ctx, in which case there's no one left to tell) and returns
+// the same error so callers can keep their existing `return ..., err`
+// shape at the call site.
+func (p *OpenAIProvider) emitFatal(ctx context.Context, out chan<- Delta, err error) error {
+	select {
+	case out <- Delta{FinishReason: "error", Err: err}:
+	case <-ctx.Done():
+	}
+	return err
+}
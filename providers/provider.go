@@ -0,0 +1,85 @@
+// Package providers defines the Provider interface that every backend
+// adapter (OpenAI-compatible, Anthropic, Ollama, Gemini, ...) implements,
+// along with the shared message/delta types used to translate conversation
+// history into each provider's native wire format.
+package providers
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChatMessage is the backend-agnostic representation of a single turn in a
+// conversation. Every adapter is responsible for translating a slice of
+// these into whatever shape its upstream API expects. ToolCalls is set on
+// an assistant message that invoked tools; ToolCallID and Name identify
+// which call a "tool" role message is answering.
+type ChatMessage struct {
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	Name       string     `json:"name,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// ToolCall is one invocation the assistant asked for: "call the function
+// named Name with these JSON-encoded Arguments". ID round-trips back on
+// the ChatMessage carrying the result.
+type ToolCall struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// Tool describes one function the model may call; Schema holds the full
+// JSON Schema parameters object (type, properties, required, ...).
+type Tool struct {
+	Name        string
+	Description string
+	Schema      map[string]interface{}
+}
+
+// Delta is one incremental chunk of an assistant reply as it streams in.
+// FinishReason is non-empty on the final delta of a turn; it is
+// "tool_calls" when ToolCalls should be dispatched before continuing, and
+// "error" when the turn ended early on a fatal upstream error or after
+// exhausting retries. Err is set
+// alongside a FinishReason of "error" so callers (like the TUI) can still
+// render whatever Content had already streamed in before the failure.
+type Delta struct {
+	Content      string
+	ToolCalls    []ToolCall
+	FinishReason string
+	Err          error
+	InputTokens  int
+	OutputTokens int
+}
+
+// Options carries per-request settings that are common across providers.
+type Options struct {
+	Model string
+	Tools []Tool
+}
+
+// Provider is implemented by every backend adapter. StreamChat sends the
+// given conversation history to the backend and returns a channel of
+// incremental Deltas; the channel is closed when the turn is complete or
+// ctx is canceled. Implementations must close the channel exactly once.
+type Provider interface {
+	Name() string
+	StreamChat(ctx context.Context, messages []ChatMessage, opts Options) (<-chan Delta, error)
+}
+
+// Registry maps a provider profile name (as configured in config.toml) to
+// a constructed Provider.
+type Registry map[string]Provider
+
+// Get looks up a provider by name, returning a descriptive error if it
+// hasn't been registered.
+func (r Registry) Get(name string) (Provider, error) {
+	p, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q", name)
+	}
+	return p, nil
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// sseEvent is one dispatched Server-Sent Event, per the WHATWG EventSource
+// spec: https://html.spec.whatwg.org/multipage/server-sent-events.html
+type sseEvent struct {
+	Event string
+	ID    string
+	Retry int // milliseconds; 0 if the server didn't send one
+	Data  string
+}
+
+// scanSSE reads r as a stream of SSE events, calling onEvent for each one
+// as it completes (on a blank line). Per spec: lines starting with ":"
+// are comments and ignored; multiple "data:" lines in the same event are
+// joined with "\n"; a missing final blank line still dispatches whatever
+// was accumulated. onEvent may return stop=true to end reading early
+// (e.g. on a "[DONE]" sentinel) without that counting as a read error.
+//
+// scanSSE returns whatever error bufio.Scanner surfaced reading r — a
+// nil error (clean EOF) is indistinguishable from the peer simply closing
+// the connection, so callers that care about mid-stream drops should
+// treat "scanSSE returned before the caller saw a finish reason" as the
+// reconnect signal, not the error value alone.
+func scanSSE(r io.Reader, onEvent func(sseEvent) (stop bool)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var ev sseEvent
+	var data strings.Builder
+	dispatch := func() bool {
+		if data.Len() == 0 && ev.Event == "" && ev.ID == "" {
+			return false
+		}
+		ev.Data = strings.TrimSuffix(data.String(), "\n")
+		stop := onEvent(ev)
+		ev = sseEvent{}
+		data.Reset()
+		return stop
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if dispatch() {
+				return nil
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // comment line, per spec
+		}
+
+		field, value := splitSSEField(line)
+		switch field {
+		case "data":
+			data.WriteString(value)
+			data.WriteString("\n")
+		case "event":
+			ev.Event = value
+		case "id":
+			ev.ID = value
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				ev.Retry = ms
+			}
+		}
+	}
+
+	// A stream that ends without a trailing blank line still has a
+	// pending event worth delivering.
+	dispatch()
+	return scanner.Err()
+}
+
+// splitSSEField splits a raw SSE line into its field name and value,
+// honoring the spec's "a single leading space after the colon is
+// stripped" rule.
+func splitSSEField(line string) (field, value string) {
+	colon := strings.IndexByte(line, ':')
+	if colon == -1 {
+		return line, ""
+	}
+	field = line[:colon]
+	value = strings.TrimPrefix(line[colon+1:], " ")
+	return field, value
+}
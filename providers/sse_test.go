@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScanSSEJoinsMultiLineData(t *testing.T) {
+	input := "data: line one\ndata: line two\n\n"
+	var got []sseEvent
+	err := scanSSE(strings.NewReader(input), func(ev sseEvent) bool {
+		got = append(got, ev)
+		return false
+	})
+	if err != nil {
+		t.Fatalf("scanSSE: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(got))
+	}
+	if got[0].Data != "line one\nline two" {
+		t.Fatalf("unexpected data: %q", got[0].Data)
+	}
+}
+
+func TestScanSSEIgnoresCommentLines(t *testing.T) {
+	input := ": keep-alive\ndata: hello\n\n"
+	var got []sseEvent
+	err := scanSSE(strings.NewReader(input), func(ev sseEvent) bool {
+		got = append(got, ev)
+		return false
+	})
+	if err != nil {
+		t.Fatalf("scanSSE: %v", err)
+	}
+	if len(got) != 1 || got[0].Data != "hello" {
+		t.Fatalf("unexpected events: %+v", got)
+	}
+}
+
+func TestScanSSEParsesEventAndIDFieldsAndStopsEarly(t *testing.T) {
+	input := "event: ping\nid: 1\ndata: [DONE]\n\ndata: should not be seen\n\n"
+	var got []sseEvent
+	err := scanSSE(strings.NewReader(input), func(ev sseEvent) bool {
+		got = append(got, ev)
+		return ev.Data == "[DONE]"
+	})
+	if err != nil {
+		t.Fatalf("scanSSE: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected scanning to stop right after the [DONE] event, got %d events", len(got))
+	}
+	if got[0].Event != "ping" || got[0].ID != "1" {
+		t.Fatalf("unexpected event fields: %+v", got[0])
+	}
+}
+
+func TestScanSSEDispatchesTrailingEventWithoutFinalBlankLine(t *testing.T) {
+	input := "data: partial"
+	var got []sseEvent
+	err := scanSSE(strings.NewReader(input), func(ev sseEvent) bool {
+		got = append(got, ev)
+		return false
+	})
+	if err != nil {
+		t.Fatalf("scanSSE: %v", err)
+	}
+	if len(got) != 1 || got[0].Data != "partial" {
+		t.Fatalf("expected the unterminated trailing event to still be dispatched, got %+v", got)
+	}
+}
+
+func TestSplitSSEField(t *testing.T) {
+	cases := []struct {
+		line, field, value string
+	}{
+		{"data: hello", "data", "hello"},
+		{"data:hello", "data", "hello"},
+		{"event", "event", ""},
+	}
+	for _, c := range cases {
+		field, value := splitSSEField(c.line)
+		if field != c.field || value != c.value {
+			t.Errorf("splitSSEField(%q) = (%q, %q), want (%q, %q)", c.line, field, value, c.field, c.value)
+		}
+	}
+}
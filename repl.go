@@ -0,0 +1,547 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/CGH0S7/gogpt/conversations"
+	"github.com/CGH0S7/gogpt/providers"
+	"github.com/CGH0S7/gogpt/templates"
+	"github.com/CGH0S7/gogpt/tools"
+)
+
+// ANSI Color codes
+const (
+	colorCyan = "\033[36m"
+	// colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorReset  = "\033[0m"
+)
+
+const defaultSystemPrompt = "You are a helpful assistant."
+
+// ChatMessage is kept as an alias of providers.ChatMessage so existing
+// call sites throughout this package don't need to change.
+type ChatMessage = providers.ChatMessage
+
+// session holds everything the REPL loop needs across turns: the active
+// provider/model, the tool registry and active agent bundle, the
+// conversation store, and the conversation currently being edited.
+type session struct {
+	config      *Config
+	registry    providers.Registry
+	active      providers.Provider
+	activeName  string
+	activeModel string
+
+	toolRegistry *tools.Registry
+	agentName    string
+	agentPolicy  tools.Policy
+
+	store *conversations.Store
+	conv  *conversations.Conversation
+
+	reader *bufio.Reader
+}
+
+// newSession builds the shared state the plain REPL and the TUI both
+// drive: the active provider, tool registry, and a fresh conversation.
+func newSession(config *Config, registry providers.Registry, providerFlag string) (*session, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	store, err := conversations.NewStore(filepath.Join(dir, "conversations"))
+	if err != nil {
+		return nil, err
+	}
+
+	activeName := config.Provider
+	if providerFlag != "" {
+		activeName = providerFlag
+	}
+	if activeName == "" {
+		activeName = "default"
+	}
+	active, err := registry.Get(activeName)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting provider: %w", err)
+	}
+
+	s := &session{
+		config:       config,
+		registry:     registry,
+		active:       active,
+		activeName:   activeName,
+		activeModel:  modelForProfile(config, activeName),
+		toolRegistry: tools.NewRegistry(),
+		reader:       bufio.NewReader(os.Stdin),
+		store:        store,
+	}
+	tools.Confirm = s.confirmShellExec
+
+	if len(s.toolRegistry.Filter(s.agentPolicy)) > 0 && !providerSupportsTools(s.config, s.activeName) {
+		return nil, fmt.Errorf("provider %q doesn't support tool calling, but tools are enabled by default; start with a tools-capable provider, then use /agent to switch to an agent whose tools are restricted", s.activeName)
+	}
+
+	conv, err := store.Create("", defaultSystemPrompt, s.activeModel)
+	if err != nil {
+		return nil, fmt.Errorf("could not start conversation: %w", err)
+	}
+	s.conv = conv
+
+	return s, nil
+}
+
+// runREPL drives the interactive chat loop until the user exits.
+func runREPL(config *Config, registry providers.Registry, providerFlag string) error {
+	s, err := newSession(config, registry, providerFlag)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Welcome to gogpt! Type 'exit', 'quit', or press Ctrl+D to end the chat.")
+	fmt.Printf("Connected to provider '%s' using model '%s'.\n\n", s.active.Name(), s.activeModel)
+	fmt.Println("  _______   ______     _______ .______   .___________.")
+	fmt.Println(" /  _____| /  __  \\   /  _____||   _  \\  |           |")
+	fmt.Println("|  |  __  |  |  |  | |  |  __  |  |_)  | `---|  |----`")
+	fmt.Println("|  | |_ | |  |  |  | |  | |_ | |   ___/      |  |     ")
+	fmt.Println("|  |__| | |  `--'  | |  |__| | |  |          |  |     ")
+	fmt.Println(" \\______|  \\______/   \\______| | _|          |__|   \n")
+
+	for {
+		fmt.Printf("%s%s:%s ", colorYellow, config.Username, colorReset)
+		userInput, err := s.reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				fmt.Println("\nGoodbye!")
+				return nil
+			}
+			return fmt.Errorf("error reading input: %w", err)
+		}
+
+		userInput = strings.TrimSpace(userInput)
+		lower := strings.ToLower(userInput)
+
+		if lower == "exit" || lower == "quit" {
+			fmt.Println("Goodbye!")
+			return nil
+		}
+		if userInput == "" {
+			continue
+		}
+
+		if strings.HasPrefix(userInput, "/") {
+			if err := s.handleCommand(userInput); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
+			continue
+		}
+
+		if err := s.sendTurn(userInput); err != nil {
+			fmt.Printf("Error getting response: %v\n", err)
+		}
+	}
+}
+
+// handleCommand dispatches a leading-slash line to the matching REPL
+// command.
+func (s *session) handleCommand(line string) error {
+	fields := strings.Fields(line)
+	cmd := fields[0]
+	arg := strings.TrimSpace(strings.TrimPrefix(line, cmd))
+
+	switch cmd {
+	case "/provider":
+		return s.cmdProvider(arg)
+	case "/agent":
+		return s.cmdAgent(arg)
+	case "/new":
+		return s.cmdNew(arg)
+	case "/list":
+		return s.cmdList()
+	case "/open":
+		return s.cmdOpen(arg)
+	case "/rm":
+		return s.cmdRemove(arg)
+	case "/view":
+		return s.cmdView()
+	case "/edit":
+		return s.cmdEdit(arg)
+	case "/branches":
+		return s.cmdBranches()
+	case "/checkout":
+		return s.cmdCheckout(arg)
+	case "/use":
+		return s.cmdUse(arg)
+	default:
+		fmt.Printf("Unknown command: %s\n", cmd)
+		return nil
+	}
+}
+
+func (s *session) cmdProvider(name string) error {
+	if name == "" {
+		fmt.Printf("Current provider: %s (model %s)\n", s.active.Name(), s.activeModel)
+		return nil
+	}
+	if len(s.toolRegistry.Filter(s.agentPolicy)) > 0 && !providerSupportsTools(s.config, name) {
+		return fmt.Errorf("provider %q doesn't support tool calling, but the current agent enables tools", name)
+	}
+	next, err := s.registry.Get(name)
+	if err != nil {
+		return err
+	}
+	s.active = next
+	s.activeName = name
+	s.activeModel = modelForProfile(s.config, name)
+	fmt.Printf("Switched to provider '%s' using model '%s'.\n", s.active.Name(), s.activeModel)
+	return nil
+}
+
+// cmdAgent switches to a named agent bundle (system prompt + enabled
+// tools + model), or reports the active one when called with no name.
+func (s *session) cmdAgent(name string) error {
+	if name == "" {
+		if s.agentName == "" {
+			fmt.Println("No agent active; using default settings.")
+		} else {
+			fmt.Printf("Current agent: %s\n", s.agentName)
+		}
+		return nil
+	}
+
+	agent, ok := agentByName(s.config, name)
+	if !ok {
+		return fmt.Errorf("unknown agent %q", name)
+	}
+
+	providerName := s.activeName
+	if agent.Provider != "" {
+		providerName = agent.Provider
+	}
+	if len(s.toolRegistry.Filter(agent.Tools)) > 0 && !providerSupportsTools(s.config, providerName) {
+		return fmt.Errorf("agent %q enables tools, but provider %q doesn't support tool calling", agent.Name, providerName)
+	}
+
+	if agent.Provider != "" {
+		next, err := s.registry.Get(agent.Provider)
+		if err != nil {
+			return err
+		}
+		s.active = next
+		s.activeName = agent.Provider
+	}
+	if agent.Model != "" {
+		s.activeModel = agent.Model
+	}
+	s.agentName = agent.Name
+	s.agentPolicy = agent.Tools
+
+	conv, err := s.store.Create(agent.Name, agent.SystemPrompt, s.activeModel)
+	if err != nil {
+		return err
+	}
+	s.conv = conv
+	fmt.Printf("Switched to agent '%s' (provider %s, model %s)\n", agent.Name, s.active.Name(), s.activeModel)
+	return nil
+}
+
+func (s *session) cmdNew(title string) error {
+	conv, err := s.store.Create(title, defaultSystemPrompt, s.activeModel)
+	if err != nil {
+		return err
+	}
+	s.conv = conv
+	fmt.Printf("Started new conversation %s\n", conv.ID)
+	return nil
+}
+
+func (s *session) cmdList() error {
+	summaries, err := s.store.List()
+	if err != nil {
+		return err
+	}
+	for _, sum := range summaries {
+		title := sum.Title
+		if title == "" {
+			title = "(untitled)"
+		}
+		fmt.Printf("%s  %s  %s\n", sum.ID, sum.CreatedAt.Format("2006-01-02 15:04"), title)
+	}
+	return nil
+}
+
+func (s *session) cmdOpen(id string) error {
+	conv, err := s.store.Open(id)
+	if err != nil {
+		return err
+	}
+	s.conv = conv
+	fmt.Printf("Opened conversation %s\n", conv.ID)
+	return nil
+}
+
+func (s *session) cmdRemove(id string) error {
+	if err := s.store.Remove(id); err != nil {
+		return err
+	}
+	fmt.Printf("Removed conversation %s\n", id)
+	return nil
+}
+
+func (s *session) cmdView() error {
+	path, err := s.conv.Path(s.conv.Head())
+	if err != nil {
+		return err
+	}
+	for i, msg := range path {
+		fmt.Printf("[%d] %s: %s\n", i, msg.Role, msg.Content)
+	}
+	return nil
+}
+
+// cmdEdit forks the conversation from the parent of the given message
+// index, letting the user re-prompt after editing an earlier message.
+func (s *session) cmdEdit(arg string) error {
+	parts := strings.SplitN(arg, " ", 2)
+	if len(parts) < 2 {
+		return fmt.Errorf("usage: /edit <msg-index> <new content>")
+	}
+	index, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return fmt.Errorf("invalid message index %q", parts[0])
+	}
+
+	path, err := s.conv.Path(s.conv.Head())
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(path) {
+		return fmt.Errorf("message index %d out of range", index)
+	}
+	if index == 0 {
+		return fmt.Errorf("message 0 is the system prompt and has no parent to fork from; edit config.toml or start a new conversation instead")
+	}
+
+	parentID := path[index].ParentID
+	branch, err := s.store.Fork(s.conv, parentID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Forked onto branch %s\n", branch)
+
+	return s.sendTurn(parts[1])
+}
+
+func (s *session) cmdBranches() error {
+	for _, b := range s.conv.Branches {
+		marker := " "
+		if b.Name == s.conv.CurrentBranch {
+			marker = "*"
+		}
+		fmt.Printf("%s %s (head %s)\n", marker, b.Name, b.HeadID)
+	}
+	return nil
+}
+
+func (s *session) cmdCheckout(branch string) error {
+	if err := s.store.Checkout(s.conv, branch); err != nil {
+		return err
+	}
+	fmt.Printf("Switched to branch %s\n", branch)
+	return nil
+}
+
+// cmdUse renders a named prompt template (see the templates package) and
+// sends the result as the next turn. Trailing "key=value" arguments feed
+// the template's variables; a template that sets SystemPrompt or Model
+// starts a fresh conversation so it doesn't inherit the current one's
+// history or overrides.
+func (s *session) cmdUse(arg string) error {
+	fields := strings.Fields(arg)
+	if len(fields) == 0 {
+		return fmt.Errorf("usage: /use <template> [key=value ...]")
+	}
+	name := fields[0]
+
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+	store, err := templates.NewStore(filepath.Join(dir, "templates"))
+	if err != nil {
+		return err
+	}
+	tmpl, err := store.Load(name)
+	if err != nil {
+		return err
+	}
+
+	vars := map[string]string{}
+	for _, f := range fields[1:] {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return fmt.Errorf("invalid variable %q, want key=value", f)
+		}
+		vars[key] = value
+	}
+
+	prompt, err := templates.Render(tmpl, templates.Data{Vars: vars})
+	if err != nil {
+		return err
+	}
+
+	if tmpl.SystemPrompt != "" || tmpl.Model != "" {
+		model := tmpl.Model
+		if model == "" {
+			model = s.activeModel
+		}
+		systemPrompt := tmpl.SystemPrompt
+		if systemPrompt == "" {
+			systemPrompt = defaultSystemPrompt
+		}
+		conv, err := s.store.Create(tmpl.Name, systemPrompt, model)
+		if err != nil {
+			return err
+		}
+		s.conv = conv
+		prevModel := s.activeModel
+		s.activeModel = model
+		defer func() { s.activeModel = prevModel }()
+	}
+
+	return s.sendTurn(prompt)
+}
+
+// confirmShellExec asks the user to approve a shell_exec tool call before
+// it runs.
+func (s *session) confirmShellExec(command string) bool {
+	fmt.Printf("\n%sAgent wants to run:%s %s\nAllow? [y/N] ", colorYellow, colorReset, command)
+	answer, _ := s.reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// sendTurn appends the user's message, then drives the agent loop: stream
+// a reply, dispatch any tool calls the model asked for, and repeat until
+// it produces a final assistant message with no pending calls.
+func (s *session) sendTurn(userInput string) error {
+	parentID, err := s.appendMessage(s.conv.Head(), "user", userInput)
+	if err != nil {
+		return err
+	}
+
+	// Ctrl+C aborts just this turn: the signal cancels ctx so the stream
+	// unwinds cleanly, but history up to here (and any partial reply
+	// already appended below) is kept.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	toolSchemas := s.toolRegistry.Filter(s.agentPolicy)
+
+	for {
+		history, err := s.conv.Path(parentID)
+		if err != nil {
+			return err
+		}
+
+		content, toolCalls, inputTokens, outputTokens, streamErr := streamChatResponse(ctx, s.active, s.activeModel, toChatMessages(history), toolSchemas)
+		if streamErr != nil {
+			// Persist whatever text streamed in before the failure so a
+			// dropped connection doesn't also lose the partial reply.
+			if content != "" {
+				s.store.AppendMessage(s.conv, parentID, "assistant", content, s.activeModel, inputTokens, outputTokens)
+			}
+			return streamErr
+		}
+
+		if len(toolCalls) == 0 {
+			_, err := s.store.AppendMessage(s.conv, parentID, "assistant", content, s.activeModel, inputTokens, outputTokens)
+			return err
+		}
+
+		assistantMsg, err := s.store.AppendToolCalls(s.conv, parentID, content, s.activeModel, toolCalls, inputTokens, outputTokens)
+		if err != nil {
+			return err
+		}
+		parentID = assistantMsg.ID
+
+		for _, call := range toolCalls {
+			fmt.Printf("\n%s→ calling %s(%s)%s\n", colorCyan, call.Name, call.Arguments, colorReset)
+			result := s.toolRegistry.Dispatch(ctx, s.agentPolicy, call.Name, json.RawMessage(call.Arguments))
+			toolMsg, err := s.store.AppendToolResult(s.conv, parentID, call.Name, call.ID, result)
+			if err != nil {
+				return err
+			}
+			parentID = toolMsg.ID
+		}
+	}
+}
+
+// appendMessage is a small convenience wrapper around
+// conversations.Store.AppendMessage that returns just the new ID.
+func (s *session) appendMessage(parentID, role, content string) (string, error) {
+	msg, err := s.store.AppendMessage(s.conv, parentID, role, content, s.activeModel, 0, 0)
+	if err != nil {
+		return "", err
+	}
+	return msg.ID, nil
+}
+
+// toChatMessages converts stored conversation messages into the shared
+// ChatMessage type Providers expect.
+func toChatMessages(history []conversations.Message) []ChatMessage {
+	msgs := make([]ChatMessage, len(history))
+	for i, m := range history {
+		msgs[i] = ChatMessage{Role: m.Role, Content: m.Content, Name: m.Name, ToolCallID: m.ToolCallID, ToolCalls: m.ToolCalls}
+	}
+	return msgs
+}
+
+// streamChatResponse sends messages to the active provider and prints the
+// response token-by-token as it streams in. It returns the accumulated
+// text, if the model asked to call tools instead of finishing the
+// requested ToolCalls, and the usage the provider reported (0 if it
+// never sent any).
+func streamChatResponse(ctx context.Context, p providers.Provider, model string, messages []ChatMessage, toolSchemas []providers.Tool) (content string, toolCalls []providers.ToolCall, inputTokens, outputTokens int, err error) {
+	deltas, err := p.StreamChat(ctx, messages, providers.Options{Model: model, Tools: toolSchemas})
+	if err != nil {
+		return "", nil, 0, 0, err
+	}
+
+	fmt.Printf("\n%sGoGPT:%s\n%s", colorCyan, colorReset, colorReset)
+	var fullResponse strings.Builder
+	var streamErr error
+	for delta := range deltas {
+		if delta.Content != "" {
+			fmt.Print(delta.Content)
+			fullResponse.WriteString(delta.Content)
+		}
+		if len(delta.ToolCalls) > 0 {
+			toolCalls = delta.ToolCalls
+		}
+		if delta.InputTokens > 0 {
+			inputTokens = delta.InputTokens
+		}
+		if delta.OutputTokens > 0 {
+			outputTokens = delta.OutputTokens
+		}
+		if delta.Err != nil {
+			streamErr = delta.Err
+		}
+	}
+	fmt.Printf("%s\n\n", colorReset)
+
+	// Even on failure, fullResponse carries whatever text streamed in
+	// before the error so the caller (and the TUI) can still show it.
+	return fullResponse.String(), toolCalls, inputTokens, outputTokens, streamErr
+}
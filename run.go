@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CGH0S7/gogpt/providers"
+	"github.com/CGH0S7/gogpt/templates"
+)
+
+// runRun implements `gogpt run <template> [--var key=value ...]`: renders
+// a named prompt template and sends it to the configured provider as a
+// single one-shot turn, streaming the reply to stdout.
+func runRun(config *Config, registry providers.Registry, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: gogpt run <template> [--var key=value ...]")
+	}
+	name := args[0]
+
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	var vars varFlag
+	fs.Var(&vars, "var", "template variable as key=value (repeatable)")
+	providerName := fs.String("provider", config.Provider, "provider profile to use")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+	store, err := templates.NewStore(filepath.Join(dir, "templates"))
+	if err != nil {
+		return err
+	}
+	tmpl, err := store.Load(name)
+	if err != nil {
+		return err
+	}
+
+	prompt, err := templates.Render(tmpl, templates.Data{Vars: vars.values, Stdin: readStdinIfPiped()})
+	if err != nil {
+		return err
+	}
+
+	pname := *providerName
+	if pname == "" {
+		pname = "default"
+	}
+	active, err := registry.Get(pname)
+	if err != nil {
+		return fmt.Errorf("error selecting provider: %w", err)
+	}
+
+	model := tmpl.Model
+	if model == "" {
+		model = modelForProfile(config, pname)
+	}
+	systemPrompt := tmpl.SystemPrompt
+	if systemPrompt == "" {
+		systemPrompt = defaultSystemPrompt
+	}
+
+	messages := []providers.ChatMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: prompt},
+	}
+
+	deltas, err := active.StreamChat(context.Background(), messages, providers.Options{Model: model})
+	if err != nil {
+		return err
+	}
+	for delta := range deltas {
+		if delta.Content != "" {
+			fmt.Print(delta.Content)
+		}
+		if delta.Err != nil {
+			return delta.Err
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// readStdinIfPiped returns whatever was piped into stdin, or "" when
+// stdin is a terminal (nothing to read).
+func readStdinIfPiped() string {
+	if isTTY(os.Stdin) {
+		return ""
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// varFlag collects repeated --var key=value flags into a map.
+type varFlag struct {
+	values map[string]string
+}
+
+func (v *varFlag) String() string {
+	return ""
+}
+
+func (v *varFlag) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("invalid --var %q, want key=value", s)
+	}
+	if v.values == nil {
+		v.values = map[string]string{}
+	}
+	v.values[key] = value
+	return nil
+}
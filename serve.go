@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/CGH0S7/gogpt/providers"
+	"github.com/CGH0S7/gogpt/server"
+)
+
+// runServe implements `gogpt serve`: an HTTP server that proxies
+// OpenAI-compatible requests to the configured upstream provider.
+func runServe(config *Config, registry providers.Registry, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", "127.0.0.1:8081", "address to listen on")
+	token := fs.String("token", "", "require this bearer token on every request (optional)")
+	providerName := fs.String("provider", config.Provider, "provider profile to serve")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	name := *providerName
+	if name == "" {
+		name = "default"
+	}
+	active, err := registry.Get(name)
+	if err != nil {
+		return fmt.Errorf("error selecting provider: %w", err)
+	}
+
+	srv := server.New(active, modelForProfile(config, name))
+	srv.AuthToken = *token
+	srv.Use(server.LoggingMiddleware)
+
+	fmt.Printf("gogpt serve: listening on %s, proxying to provider '%s'\n", *listen, active.Name())
+	return srv.ListenAndServe(*listen)
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/CGH0S7/gogpt/providers"
+)
+
+// chatCompletionRequest mirrors the subset of OpenAI's request body gogpt
+// understands. Unknown fields are ignored.
+type chatCompletionRequest struct {
+	Model    string                  `json:"model"`
+	Messages []providers.ChatMessage `json:"messages"`
+	Stream   bool                    `json:"stream"`
+}
+
+type chatCompletionChoice struct {
+	Index        int                    `json:"index"`
+	Message      *providers.ChatMessage `json:"message,omitempty"`
+	Delta        *providers.ChatMessage `json:"delta,omitempty"`
+	FinishReason *string                `json:"finish_reason"`
+}
+
+type chatCompletionChunk struct {
+	Object  string                  `json:"object"`
+	Model   string                  `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+}
+
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	var req chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.Model == "" {
+		req.Model = s.DefaultModel
+	}
+
+	deltas, err := s.Provider.StreamChat(r.Context(), req.Messages, providers.Options{Model: req.Model})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("upstream error: %v", err))
+		return
+	}
+
+	if req.Stream {
+		s.streamCompletions(w, req.Model, deltas)
+		return
+	}
+	s.writeAggregateCompletion(w, req.Model, deltas)
+}
+
+// streamCompletions re-emits the upstream deltas as OpenAI-shaped SSE
+// chunks so streaming clients work unmodified.
+func (s *Server) streamCompletions(w http.ResponseWriter, model string, deltas <-chan providers.Delta) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported by this server")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for delta := range deltas {
+		chunk := chatCompletionChunk{
+			Object: "chat.completion.chunk",
+			Model:  model,
+			Choices: []chatCompletionChoice{{
+				Delta: &providers.ChatMessage{Content: delta.Content},
+			}},
+		}
+		if delta.FinishReason != "" {
+			reason := delta.FinishReason
+			chunk.Choices[0].FinishReason = &reason
+		}
+
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+	}
+
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+// writeAggregateCompletion drains deltas into a single non-streaming
+// response, as OpenAI clients expect when "stream" is false or absent.
+func (s *Server) writeAggregateCompletion(w http.ResponseWriter, model string, deltas <-chan providers.Delta) {
+	var content string
+	finishReason := "stop"
+	for delta := range deltas {
+		content += delta.Content
+		if delta.FinishReason != "" {
+			finishReason = delta.FinishReason
+		}
+	}
+
+	resp := chatCompletionChunk{
+		Object: "chat.completion",
+		Model:  model,
+		Choices: []chatCompletionChoice{{
+			Message:      &providers.ChatMessage{Role: "assistant", Content: content},
+			FinishReason: &finishReason,
+		}},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// handleModels answers /v1/models with the single upstream model gogpt is
+// configured to use, in the shape OpenAI-compatible clients expect.
+func (s *Server) handleModels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"object": "list",
+		"data": []map[string]interface{}{
+			{"id": s.DefaultModel, "object": "model", "owned_by": s.Provider.Name()},
+		},
+	})
+}
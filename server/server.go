@@ -0,0 +1,90 @@
+// Package server implements `gogpt serve`: an HTTP server that speaks the
+// OpenAI /v1/chat/completions and /v1/models API, forwarding every
+// request to whichever Provider gogpt was configured with. This lets
+// other OpenAI-compatible clients point at one local endpoint that
+// already has the user's upstream credentials. Requests are forwarded
+// as-is: they are not saved to the conversation store, and no tools or
+// tool policy are applied.
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/CGH0S7/gogpt/providers"
+)
+
+// Middleware wraps an http.Handler, for cross-cutting concerns like
+// request logging or future rate-limiting/PII-scrubbing.
+type Middleware func(http.Handler) http.Handler
+
+// Server proxies OpenAI-compatible requests to a single upstream
+// Provider. AuthToken, if set, is required as a bearer token on every
+// request.
+type Server struct {
+	Provider     providers.Provider
+	DefaultModel string
+	AuthToken    string
+
+	middleware []Middleware
+}
+
+// New returns a Server that forwards to provider, answering with
+// defaultModel for requests that don't specify one.
+func New(provider providers.Provider, defaultModel string) *Server {
+	return &Server{Provider: provider, DefaultModel: defaultModel}
+}
+
+// Use registers a middleware to run on every request, in the order added.
+func (s *Server) Use(mw Middleware) {
+	s.middleware = append(s.middleware, mw)
+}
+
+// Handler builds the routed http.Handler, with middleware and auth
+// applied.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/chat/completions", s.handleChatCompletions)
+	mux.HandleFunc("/v1/models", s.handleModels)
+
+	var h http.Handler = mux
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		h = s.middleware[i](h)
+	}
+	return s.withAuth(h)
+}
+
+// ListenAndServe starts the HTTP server on addr, blocking until it exits.
+func (s *Server) ListenAndServe(addr string) error {
+	if err := http.ListenAndServe(addr, s.Handler()); err != nil {
+		return fmt.Errorf("server stopped: %w", err)
+	}
+	return nil
+}
+
+// withAuth enforces the configured bearer token, if any, ahead of every
+// route.
+func (s *Server) withAuth(next http.Handler) http.Handler {
+	if s.AuthToken == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "Bearer " + s.AuthToken
+		got := r.Header.Get("Authorization")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"message": message},
+	})
+}
@@ -0,0 +1,29 @@
+package templates
+
+// builtins returns the templates gogpt ships out of the box. NewStore
+// seeds these onto disk the first time it runs so the user has something
+// to inspect and edit, the same way loadOrInitConfig seeds config.toml.
+func builtins() []Template {
+	return []Template{
+		{
+			Name:         "explain",
+			SystemPrompt: "You are a terminal assistant. Explain shell commands precisely: what the command does overall, what each flag contributes, and a realistic example of when you'd reach for it.",
+			Prompt:       "Explain this shell command:\n\n{{.Vars.cmd}}\n",
+		},
+		{
+			Name:         "commit",
+			SystemPrompt: "You write conventional-commit messages from a git diff. Respond with only the commit message, no commentary or surrounding text.",
+			Prompt:       "Write a conventional-commit message for this staged diff:\n\n{{run \"git diff --staged\"}}\n",
+		},
+		{
+			Name:         "review",
+			SystemPrompt: "You are a meticulous code reviewer. Point out bugs, unclear naming, and missed edge cases; skip style nits unless they hurt readability.",
+			Prompt:       "Review this code:\n\n{{stdin}}\n",
+		},
+		{
+			Name:         "summarize",
+			SystemPrompt: "You summarize text clearly and concisely, preserving the key points and omitting filler.",
+			Prompt:       "Summarize the following:\n\n{{stdin}}\n",
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+)
+
+// Data carries per-invocation inputs into a template: the --var flags (or
+// `/use` arguments) keyed by name, and whatever text was piped into
+// stdin.
+type Data struct {
+	Vars  map[string]string
+	Stdin string
+}
+
+// Render executes tmpl.Prompt as a Go text/template against data, with
+// helpers for pulling in file contents, command output, and the piped
+// stdin. Variables are referenced directly as {{.Vars.key}}.
+func Render(tmpl Template, data Data) (string, error) {
+	funcs := template.FuncMap{
+		"file": func(path string) (string, error) {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return "", fmt.Errorf("could not read %s: %w", path, err)
+			}
+			return string(b), nil
+		},
+		"run": func(command string) (string, error) {
+			out, err := exec.Command("sh", "-c", command).CombinedOutput()
+			if err != nil {
+				return "", fmt.Errorf("command %q failed: %w\noutput: %s", command, err, string(out))
+			}
+			return strings.TrimRight(string(out), "\n"), nil
+		},
+		"stdin": func() string { return data.Stdin },
+	}
+
+	t, err := template.New(tmpl.Name).Funcs(funcs).Parse(tmpl.Prompt)
+	if err != nil {
+		return "", fmt.Errorf("could not parse template %q: %w", tmpl.Name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("could not render template %q: %w", tmpl.Name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,117 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Store reads and writes templates as one TOML file per name under Dir.
+type Store struct {
+	Dir string
+}
+
+// NewStore returns a Store rooted at dir, creating it and seeding the
+// built-in templates on first use. Existing files are left untouched, so
+// a user who edits a built-in (e.g. "commit") keeps their changes across
+// restarts.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("could not create templates directory: %w", err)
+	}
+	s := &Store{Dir: dir}
+
+	for _, t := range builtins() {
+		p, err := s.path(t.Name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(p); os.IsNotExist(err) {
+			if err := s.save(t); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// validName reports whether name is safe to use as a template filename:
+// non-empty and made up only of letters, digits, '-', and '_'. Template
+// names come from user input (`gogpt run <name>`, `/use <name>`) and
+// must not be able to escape the templates directory via "/" or "..",
+// the same class of bug fixed for conversation IDs in
+// conversations.Store.path.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Store) path(name string) (string, error) {
+	if !validName(name) {
+		return "", fmt.Errorf("invalid template name %q", name)
+	}
+	return filepath.Join(s.Dir, name+".toml"), nil
+}
+
+func (s *Store) save(t Template) error {
+	p, err := s.path(t.Name)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(p)
+	if err != nil {
+		return fmt.Errorf("could not create template %q: %w", t.Name, err)
+	}
+	defer file.Close()
+
+	if err := toml.NewEncoder(file).Encode(t); err != nil {
+		return fmt.Errorf("could not encode template %q: %w", t.Name, err)
+	}
+	return nil
+}
+
+// Load reads the named template from disk.
+func (s *Store) Load(name string) (Template, error) {
+	p, err := s.path(name)
+	if err != nil {
+		return Template{}, err
+	}
+	var t Template
+	if _, err := toml.DecodeFile(p, &t); err != nil {
+		return Template{}, fmt.Errorf("could not load template %q: %w", name, err)
+	}
+	if t.Name == "" {
+		t.Name = name
+	}
+	return t, nil
+}
+
+// List returns the names of every template on disk, built-in or
+// user-added, sorted alphabetically.
+func (s *Store) List() ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read templates directory: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".toml" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".toml"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
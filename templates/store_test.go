@@ -0,0 +1,31 @@
+package templates
+
+import "testing"
+
+func TestLoadRejectsPathTraversal(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	for _, bad := range []string{"../../../etc/passwd", "../evil", "a/b", "..", ""} {
+		if _, err := store.Load(bad); err == nil {
+			t.Errorf("Load(%q): expected error, got nil", bad)
+		}
+	}
+}
+
+func TestLoadBuiltin(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	tmpl, err := store.Load("commit")
+	if err != nil {
+		t.Fatalf("Load(\"commit\"): %v", err)
+	}
+	if tmpl.Name != "commit" {
+		t.Fatalf("Load(\"commit\").Name = %q, want %q", tmpl.Name, "commit")
+	}
+}
@@ -0,0 +1,15 @@
+// Package templates implements gogpt's named prompt library: reusable
+// Go text/template prompts, stored as TOML files under the config dir,
+// that can override the system prompt and model for a single invocation.
+package templates
+
+// Template is a named, reusable prompt. Prompt is rendered as a Go
+// text/template (see Render) to produce the user message sent to the
+// model. SystemPrompt and Model, when set, override the session's
+// current defaults for just this invocation.
+type Template struct {
+	Name         string `toml:"name"`
+	SystemPrompt string `toml:"system_prompt"`
+	Model        string `toml:"model"`
+	Prompt       string `toml:"prompt"`
+}
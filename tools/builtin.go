@@ -0,0 +1,169 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/CGH0S7/gogpt/providers"
+)
+
+// Confirm is called before shell_exec runs a command, so the TUI/REPL can
+// show the user what's about to execute and let them refuse it. It
+// defaults to auto-approving (tests and non-interactive callers can
+// override it); the REPL wires a real confirmation prompt in at startup.
+var Confirm = func(command string) bool { return true }
+
+func builtins() []Definition {
+	return []Definition{
+		readFileTool(),
+		listDirTool(),
+		shellExecTool(),
+		httpGetTool(),
+	}
+}
+
+func readFileTool() Definition {
+	return Definition{
+		Tool: providers.Tool{
+			Name:        "read_file",
+			Description: "Read the contents of a file at the given path.",
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{"type": "string", "description": "path to the file to read"},
+				},
+				"required": []string{"path"},
+			},
+		},
+		Handler: func(ctx context.Context, rawArgs json.RawMessage) (string, error) {
+			var args struct {
+				Path string `json:"path"`
+			}
+			if err := json.Unmarshal(rawArgs, &args); err != nil {
+				return "", fmt.Errorf("could not parse arguments: %w", err)
+			}
+			data, err := os.ReadFile(args.Path)
+			if err != nil {
+				return "", fmt.Errorf("could not read %s: %w", args.Path, err)
+			}
+			return string(data), nil
+		},
+	}
+}
+
+func listDirTool() Definition {
+	return Definition{
+		Tool: providers.Tool{
+			Name:        "list_dir",
+			Description: "List the files and subdirectories in a directory.",
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{"type": "string", "description": "directory to list"},
+				},
+				"required": []string{"path"},
+			},
+		},
+		Handler: func(ctx context.Context, rawArgs json.RawMessage) (string, error) {
+			var args struct {
+				Path string `json:"path"`
+			}
+			if err := json.Unmarshal(rawArgs, &args); err != nil {
+				return "", fmt.Errorf("could not parse arguments: %w", err)
+			}
+			entries, err := os.ReadDir(args.Path)
+			if err != nil {
+				return "", fmt.Errorf("could not list %s: %w", args.Path, err)
+			}
+			var sb strings.Builder
+			for _, e := range entries {
+				if e.IsDir() {
+					fmt.Fprintf(&sb, "%s/\n", e.Name())
+				} else {
+					fmt.Fprintf(&sb, "%s\n", e.Name())
+				}
+			}
+			return sb.String(), nil
+		},
+	}
+}
+
+func shellExecTool() Definition {
+	return Definition{
+		Tool: providers.Tool{
+			Name:        "shell_exec",
+			Description: "Run a shell command and return its combined stdout/stderr. Requires user confirmation.",
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"command": map[string]interface{}{"type": "string", "description": "the shell command to run"},
+				},
+				"required": []string{"command"},
+			},
+		},
+		Handler: func(ctx context.Context, rawArgs json.RawMessage) (string, error) {
+			var args struct {
+				Command string `json:"command"`
+			}
+			if err := json.Unmarshal(rawArgs, &args); err != nil {
+				return "", fmt.Errorf("could not parse arguments: %w", err)
+			}
+			if !Confirm(args.Command) {
+				return "", fmt.Errorf("command was not approved by the user")
+			}
+			cmd := exec.CommandContext(ctx, "sh", "-c", args.Command)
+			output, err := cmd.CombinedOutput()
+			if err != nil {
+				return "", fmt.Errorf("command failed: %w\noutput: %s", err, string(output))
+			}
+			return string(output), nil
+		},
+	}
+}
+
+func httpGetTool() Definition {
+	return Definition{
+		Tool: providers.Tool{
+			Name:        "http_get",
+			Description: "Fetch a URL with HTTP GET and return the response body.",
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"url": map[string]interface{}{"type": "string", "description": "URL to fetch"},
+				},
+				"required": []string{"url"},
+			},
+		},
+		Handler: func(ctx context.Context, rawArgs json.RawMessage) (string, error) {
+			var args struct {
+				URL string `json:"url"`
+			}
+			if err := json.Unmarshal(rawArgs, &args); err != nil {
+				return "", fmt.Errorf("could not parse arguments: %w", err)
+			}
+			req, err := http.NewRequestWithContext(ctx, "GET", args.URL, nil)
+			if err != nil {
+				return "", fmt.Errorf("could not create request: %w", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return "", fmt.Errorf("could not fetch %s: %w", args.URL, err)
+			}
+			defer resp.Body.Close()
+
+			const maxBody = 64 * 1024
+			body, err := io.ReadAll(io.LimitReader(bufio.NewReader(resp.Body), maxBody))
+			if err != nil {
+				return "", fmt.Errorf("could not read response body: %w", err)
+			}
+			return string(body), nil
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package tools
+
+// Policy decides which registered tools an agent is actually allowed to
+// call. Deny always wins over Allow; an empty Allow list means "every
+// tool not explicitly denied" so existing single-tool configs don't need
+// to enumerate everything.
+type Policy struct {
+	Allow []string `toml:"allow"`
+	Deny  []string `toml:"deny"`
+}
+
+// IsAllowed reports whether name may be dispatched under this policy.
+func (p Policy) IsAllowed(name string) bool {
+	for _, d := range p.Deny {
+		if d == name {
+			return false
+		}
+	}
+	if len(p.Allow) == 0 {
+		return true
+	}
+	for _, a := range p.Allow {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed filters candidates down to the ones this policy permits,
+// preserving their relative order.
+func (p Policy) Allowed(candidates []string) []string {
+	var out []string
+	for _, c := range candidates {
+		if p.IsAllowed(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
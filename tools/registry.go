@@ -0,0 +1,89 @@
+// Package tools implements gogpt's pluggable tool registry: the built-in
+// handlers an agent can call (read_file, list_dir, shell_exec, http_get),
+// and the allow/deny policy that gates which ones are actually enabled.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/CGH0S7/gogpt/providers"
+)
+
+// Handler executes one tool call against its raw JSON arguments and
+// returns the text to feed back to the model as the tool result.
+type Handler func(ctx context.Context, args json.RawMessage) (string, error)
+
+// Definition pairs a tool's schema (as advertised to the model) with the
+// Go function that actually runs it.
+type Definition struct {
+	providers.Tool
+	Handler Handler
+}
+
+// Registry is the full set of tools gogpt knows how to run, keyed by
+// name. Policy decides which of them a given agent may actually invoke.
+type Registry struct {
+	defs map[string]Definition
+}
+
+// NewRegistry returns a Registry pre-populated with gogpt's built-in
+// tools.
+func NewRegistry() *Registry {
+	r := &Registry{defs: map[string]Definition{}}
+	for _, d := range builtins() {
+		r.defs[d.Name] = d
+	}
+	return r
+}
+
+// Register adds or overrides a tool definition.
+func (r *Registry) Register(d Definition) {
+	r.defs[d.Name] = d
+}
+
+// Lookup returns the definition for name, if any.
+func (r *Registry) Lookup(name string) (Definition, bool) {
+	d, ok := r.defs[name]
+	return d, ok
+}
+
+// Filter returns the Tool schemas (for advertising to the model) for the
+// names allowed by policy. The order of the result is not defined.
+func (r *Registry) Filter(policy Policy) []providers.Tool {
+	var out []providers.Tool
+	for _, name := range policy.Allowed(r.names()) {
+		if d, ok := r.defs[name]; ok {
+			out = append(out, d.Tool)
+		}
+	}
+	return out
+}
+
+func (r *Registry) names() []string {
+	names := make([]string, 0, len(r.defs))
+	for name := range r.defs {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Dispatch runs the named tool, returning an error result as plain text
+// (rather than a Go error) when the tool itself fails or isn't
+// allowed, so the caller can always append it as a "tool" role message
+// and let the model react to the failure.
+func (r *Registry) Dispatch(ctx context.Context, policy Policy, name string, args json.RawMessage) string {
+	if !policy.IsAllowed(name) {
+		return fmt.Sprintf("error: tool %q is not permitted by the current agent's policy", name)
+	}
+	d, ok := r.defs[name]
+	if !ok {
+		return fmt.Sprintf("error: unknown tool %q", name)
+	}
+	result, err := d.Handler(ctx, args)
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	return result
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// editorCommand shells out to $EDITOR (falling back to vi) against a
+// scratch file in the OS temp directory, matching the usual terminal
+// convention for "compose in my editor".
+func editorCommand() *exec.Cmd {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	return exec.Command(editor, scratchPath())
+}
+
+// scratchPath is namespaced by PID so two concurrent `gogpt tui` sessions
+// (or another local user) don't collide on the same compose file.
+func scratchPath() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gogpt-compose-%d.md", os.Getpid()))
+}
+
+// readEditorScratchFile reads back whatever the user saved after
+// openExternalEditor's $EDITOR process exits.
+func readEditorScratchFile() (string, error) {
+	data, err := os.ReadFile(scratchPath())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
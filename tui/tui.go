@@ -0,0 +1,307 @@
+// Package tui implements gogpt's optional full-screen interface, built on
+// bubbletea. It replaces the plain bufio REPL with a scrollable message
+// viewport, a multi-line editor pane, a sidebar of saved conversations,
+// and a status line, for users who'd rather not type into a bare prompt.
+// The line-based REPL remains the default for pipes and scripting; the
+// TUI is opt-in via `gogpt tui` or `tui = true` in config.toml.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/chroma/quick"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/CGH0S7/gogpt/conversations"
+	"github.com/CGH0S7/gogpt/providers"
+)
+
+const sidebarWidth = 28
+
+var (
+	sidebarStyle = lipgloss.NewStyle().Width(sidebarWidth).Border(lipgloss.NormalBorder(), false, true, false, false)
+	statusStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	activeStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86"))
+)
+
+// Backend is the subset of gogpt's session the TUI needs: sending a turn,
+// persisting its reply once the stream finishes, and listing/opening
+// saved conversations. main wires this up to the same Provider/Store the
+// plain REPL uses.
+type Backend interface {
+	Send(input string) (<-chan providers.Delta, error)
+	Complete(content string, toolCalls []providers.ToolCall, inputTokens, outputTokens int) error
+	Conversations() ([]conversations.Summary, error)
+	ProviderName() string
+	Model() string
+}
+
+// Model is the bubbletea root model for the TUI.
+type Model struct {
+	backend Backend
+
+	viewport viewport.Model
+	editor   textarea.Model
+	sidebar  []conversations.Summary
+
+	transcript strings.Builder
+	width      int
+	height     int
+
+	// pendingReply, pendingToolCalls, and the pendingTokens pair
+	// accumulate the assistant's reply as its deltas arrive, so Complete
+	// can be called with the full picture once the turn finishes; usage
+	// in particular tends to arrive on an earlier delta than the one
+	// that finally carries FinishReason.
+	pendingReply        strings.Builder
+	pendingToolCalls    []providers.ToolCall
+	pendingInputTokens  int
+	pendingOutputTokens int
+
+	tokenCount int
+	err        error
+}
+
+// New constructs the initial Model. Call tea.NewProgram(m).Run() to start
+// it (wired up in main via `gogpt tui`).
+func New(backend Backend) Model {
+	vp := viewport.New(0, 0)
+	ed := textarea.New()
+	ed.Placeholder = "Type a message, or press ctrl+e to open $EDITOR..."
+	ed.Focus()
+
+	return Model{backend: backend, viewport: vp, editor: ed}
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(textarea.Blink, m.loadConversations())
+}
+
+type responseMsg struct {
+	chunks <-chan providers.Delta
+	err    error
+}
+
+// conversationsMsg carries the result of refreshing the sidebar's list of
+// saved conversations.
+type conversationsMsg struct {
+	summaries []conversations.Summary
+	err       error
+}
+
+func (m Model) loadConversations() tea.Cmd {
+	return func() tea.Msg {
+		summaries, err := m.backend.Conversations()
+		return conversationsMsg{summaries: summaries, err: err}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.viewport.Width = msg.Width - sidebarWidth
+		m.viewport.Height = msg.Height - 6
+		m.editor.SetWidth(msg.Width - sidebarWidth)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "j":
+			m.viewport.LineDown(1)
+			return m, nil
+		case "k":
+			m.viewport.LineUp(1)
+			return m, nil
+		case "ctrl+e":
+			return m, m.openExternalEditor()
+		case "enter":
+			input := strings.TrimSpace(m.editor.Value())
+			if input == "" {
+				return m, nil
+			}
+			if strings.HasPrefix(input, "/") {
+				// Session-management commands (/new, /open, /agent, ...)
+				// only exist in the plain REPL today; sending them as chat
+				// input would confuse the model instead of doing nothing.
+				m.err = fmt.Errorf("slash commands aren't supported in the TUI yet; run %q in the plain REPL (gogpt) instead", strings.Fields(input)[0])
+				return m, nil
+			}
+			m.editor.Reset()
+			return m, m.send(input)
+		}
+
+	case conversationsMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.sidebar = msg.summaries
+		return m, nil
+
+	case responseMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, m.drain(msg.chunks)
+
+	case deltaMsg:
+		if msg.content != "" {
+			m.transcript.WriteString(msg.content)
+			m.pendingReply.WriteString(msg.content)
+			m.viewport.SetContent(renderTranscript(m.transcript.String()))
+			m.viewport.GotoBottom()
+		}
+		if len(msg.toolCalls) > 0 {
+			m.pendingToolCalls = msg.toolCalls
+		}
+		if msg.inputTokens > 0 {
+			m.pendingInputTokens = msg.inputTokens
+		}
+		if msg.outputTokens > 0 {
+			m.pendingOutputTokens = msg.outputTokens
+		}
+		if !msg.done {
+			return m, m.drain(msg.chunks)
+		}
+
+		reply, toolCalls := m.pendingReply.String(), m.pendingToolCalls
+		inputTokens, outputTokens := m.pendingInputTokens, m.pendingOutputTokens
+		m.pendingReply.Reset()
+		m.pendingToolCalls = nil
+		m.pendingInputTokens, m.pendingOutputTokens = 0, 0
+		m.tokenCount = inputTokens + outputTokens
+
+		if err := m.backend.Complete(reply, toolCalls, inputTokens, outputTokens); err != nil {
+			m.err = err
+		}
+		return m, m.loadConversations()
+	}
+
+	var cmd tea.Cmd
+	m.editor, cmd = m.editor.Update(msg)
+	return m, cmd
+}
+
+type deltaMsg struct {
+	content      string
+	toolCalls    []providers.ToolCall
+	inputTokens  int
+	outputTokens int
+	done         bool
+	chunks       <-chan providers.Delta
+}
+
+func (m Model) send(input string) tea.Cmd {
+	m.transcript.WriteString("\nyou: " + input + "\ngogpt: ")
+	return func() tea.Msg {
+		chunks, err := m.backend.Send(input)
+		return responseMsg{chunks: chunks, err: err}
+	}
+}
+
+func (m Model) drain(chunks <-chan providers.Delta) tea.Cmd {
+	return func() tea.Msg {
+		delta, ok := <-chunks
+		if !ok {
+			return deltaMsg{done: true}
+		}
+		return deltaMsg{
+			content:      delta.Content,
+			toolCalls:    delta.ToolCalls,
+			inputTokens:  delta.InputTokens,
+			outputTokens: delta.OutputTokens,
+			chunks:       chunks,
+			done:         delta.FinishReason != "",
+		}
+	}
+}
+
+// openExternalEditor suspends the TUI and shells out to $EDITOR, as
+// bubbletea's tea.ExecProcess hook is designed for.
+func (m Model) openExternalEditor() tea.Cmd {
+	cmd := editorCommand()
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return responseMsg{err: err}
+		}
+		content, _ := readEditorScratchFile()
+		m.editor.SetValue(content)
+		return nil
+	})
+}
+
+func (m Model) View() string {
+	status := statusStyle.Render(fmt.Sprintf("provider=%s model=%s tokens=%d", m.backend.ProviderName(), m.backend.Model(), m.tokenCount))
+	if m.err != nil {
+		status = statusStyle.Render(fmt.Sprintf("error: %v", m.err))
+	}
+
+	main := lipgloss.JoinVertical(lipgloss.Left, m.viewport.View(), m.editor.View(), status)
+	side := sidebarStyle.Height(m.height).Render(renderSidebar(m.sidebar))
+	return lipgloss.JoinHorizontal(lipgloss.Top, side, main)
+}
+
+func renderSidebar(summaries []conversations.Summary) string {
+	var sb strings.Builder
+	sb.WriteString(activeStyle.Render("Conversations"))
+	sb.WriteString("\n")
+	for _, s := range summaries {
+		title := s.Title
+		if title == "" {
+			title = "(untitled)"
+		}
+		fmt.Fprintf(&sb, "%s\n", title)
+	}
+	return sb.String()
+}
+
+// renderTranscript runs fenced code blocks through chroma for syntax
+// highlighting before handing the rest to the viewport untouched.
+func renderTranscript(text string) string {
+	var out strings.Builder
+	lines := strings.Split(text, "\n")
+	inFence := false
+	var lang string
+	var block strings.Builder
+
+	flush := func() {
+		if block.Len() == 0 {
+			return
+		}
+		var highlighted strings.Builder
+		if err := quick.Highlight(&highlighted, block.String(), lang, "terminal256", "monokai"); err == nil {
+			out.WriteString(highlighted.String())
+		} else {
+			out.WriteString(block.String())
+		}
+		block.Reset()
+	}
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			if inFence {
+				flush()
+				inFence = false
+			} else {
+				inFence = true
+				lang = strings.TrimPrefix(line, "```")
+			}
+			continue
+		}
+		if inFence {
+			block.WriteString(line + "\n")
+		} else {
+			out.WriteString(line + "\n")
+		}
+	}
+	flush()
+	return out.String()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+
+	"github.com/CGH0S7/gogpt/conversations"
+	"github.com/CGH0S7/gogpt/providers"
+)
+
+// tuiBackend adapts a session to tui.Backend, so the full-screen UI can
+// drive the same provider/conversation store the plain REPL uses.
+// pendingParentID tracks the message Complete should attach its reply to;
+// it's set by Send and consumed by the matching Complete call once the
+// model's response finishes streaming.
+type tuiBackend struct {
+	s               *session
+	pendingParentID string
+}
+
+// Send never advertises tools to the provider: runTUI refuses to start
+// against an agent with tools enabled (there's no dispatch loop here the
+// way sendTurn has in repl.go), so there's nothing for Options.Tools to
+// carry.
+func (b *tuiBackend) Send(input string) (<-chan providers.Delta, error) {
+	parentID, err := b.s.appendMessage(b.s.conv.Head(), "user", input)
+	if err != nil {
+		return nil, err
+	}
+	b.pendingParentID = parentID
+
+	history, err := b.s.conv.Path(parentID)
+	if err != nil {
+		return nil, err
+	}
+	return b.s.active.StreamChat(context.Background(), toChatMessages(history), providers.Options{Model: b.s.activeModel})
+}
+
+// Complete persists the assistant's reply to the conversation once its
+// turn finishes streaming, mirroring sendTurn's bookkeeping in repl.go so
+// turn 2+ still sees turn 1's reply (and, against Anthropic, doesn't hand
+// the API two consecutive "user" messages).
+func (b *tuiBackend) Complete(content string, toolCalls []providers.ToolCall, inputTokens, outputTokens int) error {
+	if len(toolCalls) == 0 {
+		_, err := b.s.store.AppendMessage(b.s.conv, b.pendingParentID, "assistant", content, b.s.activeModel, inputTokens, outputTokens)
+		return err
+	}
+	_, err := b.s.store.AppendToolCalls(b.s.conv, b.pendingParentID, content, b.s.activeModel, toolCalls, inputTokens, outputTokens)
+	return err
+}
+
+func (b *tuiBackend) Conversations() ([]conversations.Summary, error) {
+	return b.s.store.List()
+}
+
+func (b *tuiBackend) ProviderName() string { return b.s.active.Name() }
+func (b *tuiBackend) Model() string        { return b.s.activeModel }
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CGH0S7/gogpt/providers"
+	"github.com/CGH0S7/gogpt/tui"
+)
+
+// runTUI starts the full-screen interface (`gogpt tui`, or the plain REPL
+// falling through to this when stdout is a TTY and `tui = true` in
+// config.toml).
+func runTUI(config *Config, registry providers.Registry, providerFlag string) error {
+	s, err := newSession(config, registry, providerFlag)
+	if err != nil {
+		return err
+	}
+	if s.agentName != "" && len(s.toolRegistry.Filter(s.agentPolicy)) > 0 {
+		return fmt.Errorf("the TUI doesn't support tool-calling agents yet; use the plain REPL (gogpt) instead")
+	}
+
+	program := tea.NewProgram(tui.New(&tuiBackend{s: s}), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("tui exited with error: %w", err)
+	}
+	return nil
+}